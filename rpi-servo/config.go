@@ -0,0 +1,44 @@
+//go:build linux && (arm64 || arm) && !no_pigpio && !no_cgo
+
+package rpiservo
+
+import (
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+var errPinRequired = errors.New("need pin for pi servo")
+
+// ServoConfig is the configuration for a pi servo driven directly by pigpio.
+type ServoConfig struct {
+	Pin         string   `json:"pin"`
+	Min         int      `json:"min,omitempty"`
+	Max         int      `json:"max,omitempty"`
+	StartPos    *float64 `json:"starting_position_degs,omitempty"`
+	HoldPos     *bool    `json:"hold_position,omitempty"`
+	MaxRotation int      `json:"max_rotation_deg,omitempty"`
+
+	// MaxSpeedDegPerSec, when set together with a Profile of "linear" or
+	// "trapezoidal", makes Move interpolate toward the target angle instead of
+	// writing the target pulse width immediately.
+	MaxSpeedDegPerSec float64 `json:"max_speed_deg_per_sec,omitempty"`
+	// Profile selects the motion profile Move uses when MaxSpeedDegPerSec is
+	// set: "instant" (default), "linear", or "trapezoidal".
+	Profile string `json:"profile,omitempty"`
+	// AccelDegPerSec2 is the acceleration used by the "trapezoidal" profile.
+	AccelDegPerSec2 float64 `json:"accel_deg_per_sec2,omitempty"`
+}
+
+// Validate validates the config and returns implicit dependencies.
+func (conf *ServoConfig) Validate(path string) ([]string, error) {
+	if conf.Pin == "" {
+		return nil, resource.NewConfigValidationError(path, errPinRequired)
+	}
+	switch conf.Profile {
+	case "", profileInstant, profileLinear, profileTrapezoidal:
+	default:
+		return nil, resource.NewConfigValidationError(path, errors.Errorf("unknown profile %q", conf.Profile))
+	}
+	return []string{}, nil
+}
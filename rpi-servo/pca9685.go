@@ -0,0 +1,357 @@
+//go:build linux && (arm64 || arm) && !no_pigpio && !no_cgo
+
+package rpiservo
+
+/*
+	This driver controls servos attached to a PCA9685 16-channel, 12-bit PWM
+	controller over I2C. It shares the I2C connection opened through the
+	pigpio daemon with every other PCA9685-backed servo on the same bus and
+	chip address, so
+	a single Pi can drive up to 16 servos (per PCA9685) without consuming
+	GPIO pins or pigpio's limited DMA-based PWM channels.
+
+	PCA9685 datasheet:
+	https://www.nxp.com/docs/en/data-sheet/PCA9685.pdf
+*/
+
+// #include <stdlib.h>
+// #include <pigpiod_if2.h>
+// #cgo LDFLAGS: -lpigpio
+// #include "../rpi/pi.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	rpiutils "viamrpi/utils"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/servo"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
+	"go.viam.com/rdk/resource"
+)
+
+// PCA9685Model is exported for use in tests and downstream modules.
+var PCA9685Model = resource.NewModel("viam", "raspberry-pi", "pca9685-servo")
+
+// PCA9685 register addresses used by this driver.
+const (
+	pca9685Mode1     = 0x00
+	pca9685Prescale  = 0xFE
+	pca9685Led0OnL   = 0x06
+	pca9685RegsPerCh = 4
+
+	pca9685ModeSleep    = 0x10
+	pca9685ModeAutoIncr = 0x20
+	pca9685ModeRestart  = 0x80
+
+	pca9685DefaultAddress = 0x40
+	pca9685DefaultFreqHz  = 50
+	pca9685OscillatorHz   = 25000000
+	pca9685TicksPerCycle  = 4096
+)
+
+// PCA9685ServoConfig is the configuration for a servo driven through a PCA9685
+// PWM controller, sharing ServoConfig's pin semantics with the I2C address of
+// the channel it is wired to instead of a GPIO pin.
+type PCA9685ServoConfig struct {
+	I2CBus      int      `json:"i2c_bus"`
+	Address     int      `json:"i2c_address,omitempty"`
+	Channel     int      `json:"channel"`
+	PWMFreq     int      `json:"pwm_freq_hz,omitempty"`
+	Min         int      `json:"min,omitempty"`
+	Max         int      `json:"max,omitempty"`
+	StartPos    *float64 `json:"starting_position_degs,omitempty"`
+	HoldPos     *bool    `json:"hold_position,omitempty"`
+	MaxRotation int      `json:"max_rotation_deg,omitempty"`
+}
+
+// Validate validates the config and returns implicit dependencies.
+func (conf *PCA9685ServoConfig) Validate(path string) ([]string, error) {
+	if conf.Channel < 0 || conf.Channel > 15 {
+		return nil, resource.NewConfigValidationError(path, errors.New("channel must be between 0 and 15"))
+	}
+	return []string{}, nil
+}
+
+func init() {
+	resource.RegisterComponent(
+		servo.API,
+		PCA9685Model,
+		resource.Registration[servo.Servo, *PCA9685ServoConfig]{
+			Constructor: newPCA9685Servo,
+		},
+	)
+}
+
+// pca9685Bus is a single pigpio daemon connection and I2C handle to a PCA9685
+// chip, shared by every servo configured on the same bus/address pair. Only
+// the first servo to acquire a given bus/address opens a connection; every
+// other servo on that chip reuses it.
+type pca9685Bus struct {
+	mu     sync.Mutex
+	piID   C.int
+	handle C.int
+	freq   int
+	refs   int
+}
+
+var (
+	pca9685BusesMu sync.Mutex
+	pca9685Buses   = map[string]*pca9685Bus{}
+)
+
+func pca9685BusKey(bus, address int) string {
+	return fmt.Sprintf("%d:%d", bus, address)
+}
+
+// acquirePCA9685Bus opens (or reuses) the I2C handle for the given bus/address
+// and initializes the chip for the requested PWM frequency the first time it
+// is acquired.
+func acquirePCA9685Bus(bus, address, freqHz int) (*pca9685Bus, error) {
+	pca9685BusesMu.Lock()
+	defer pca9685BusesMu.Unlock()
+
+	key := pca9685BusKey(bus, address)
+	if b, ok := pca9685Buses[key]; ok {
+		if b.freq != freqHz {
+			return nil, errors.Errorf(
+				"pca9685 at bus %d address %d is already running at %d Hz, cannot also run it at %d Hz: "+
+					"frequency is set per-chip from the first channel configured on it",
+				bus, address, b.freq, freqHz)
+		}
+		b.refs++
+		return b, nil
+	}
+
+	piID := C.custom_pigpio_start()
+	handle := C.i2c_open(piID, C.uint(bus), C.uint(address), 0)
+	if int(handle) < 0 {
+		C.pigpio_stop(piID)
+		return nil, rpiutils.ConvertErrorCodeToMessage(int(handle), "pca9685 i2c_open failed")
+	}
+
+	b := &pca9685Bus{piID: piID, handle: handle, freq: freqHz, refs: 1}
+	if err := b.configure(freqHz); err != nil {
+		C.i2c_close(piID, handle)
+		C.pigpio_stop(piID)
+		return nil, err
+	}
+
+	pca9685Buses[key] = b
+	return b, nil
+}
+
+// release drops a reference to the bus, closing the underlying I2C handle and
+// pigpio connection once the last servo using it has been closed.
+func (b *pca9685Bus) release() {
+	pca9685BusesMu.Lock()
+	defer pca9685BusesMu.Unlock()
+
+	b.refs--
+	if b.refs > 0 {
+		return
+	}
+	for key, bus := range pca9685Buses {
+		if bus == b {
+			delete(pca9685Buses, key)
+			break
+		}
+	}
+	C.i2c_close(b.piID, b.handle)
+	C.pigpio_stop(b.piID)
+}
+
+// configure sets the PCA9685 PWM frequency via the PRESCALE register, which
+// requires the chip to be put to sleep first.
+func (b *pca9685Bus) configure(freqHz int) error {
+	prescale := C.uchar(pca9685OscillatorHz/(pca9685TicksPerCycle*freqHz) - 1)
+
+	if err := b.writeByte(pca9685Mode1, pca9685ModeSleep); err != nil {
+		return err
+	}
+	if err := b.writeByte(pca9685Prescale, byte(prescale)); err != nil {
+		return err
+	}
+	if err := b.writeByte(pca9685Mode1, pca9685ModeAutoIncr); err != nil {
+		return err
+	}
+	time.Sleep(5 * time.Millisecond) // oscillator stabilization, per datasheet
+	return b.writeByte(pca9685Mode1, pca9685ModeAutoIncr|pca9685ModeRestart)
+}
+
+func (b *pca9685Bus) writeByte(reg C.uint, val byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	res := C.i2c_write_byte_data(b.piID, b.handle, reg, C.uint(val))
+	if res != 0 {
+		return rpiutils.ConvertErrorCodeToMessage(int(res), "pca9685 i2c write failed")
+	}
+	return nil
+}
+
+// setChannelTicks writes the on/off tick counts for a single channel.
+func (b *pca9685Bus) setChannelTicks(channel int, onTick, offTick uint16) error {
+	base := C.uint(pca9685Led0OnL + pca9685RegsPerCh*channel)
+	if err := b.writeByte(base, byte(onTick&0xFF)); err != nil {
+		return err
+	}
+	if err := b.writeByte(base+1, byte(onTick>>8)); err != nil {
+		return err
+	}
+	if err := b.writeByte(base+2, byte(offTick&0xFF)); err != nil {
+		return err
+	}
+	return b.writeByte(base+3, byte(offTick>>8))
+}
+
+// pca9685Servo implements servo.Servo for a single channel of a PCA9685.
+type pca9685Servo struct {
+	resource.Named
+	resource.AlwaysRebuild
+	logger      logging.Logger
+	opMgr       *operation.SingleOperationManager
+	bus         *pca9685Bus
+	channel     int
+	min, max    uint32
+	maxRotation uint32
+	holdPos     bool
+	pulseWidth  int
+}
+
+func newPCA9685Servo(
+	ctx context.Context,
+	_ resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (servo.Servo, error) {
+	newConf, err := resource.NativeConfig[*PCA9685ServoConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+
+	address := newConf.Address
+	if address == 0 {
+		address = pca9685DefaultAddress
+	}
+	freq := newConf.PWMFreq
+	if freq == 0 {
+		freq = pca9685DefaultFreqHz
+	}
+
+	bus, err := acquirePCA9685Bus(newConf.I2CBus, address, freq)
+	if err != nil {
+		return nil, err
+	}
+
+	theServo := &pca9685Servo{
+		Named:   conf.ResourceName().AsNamed(),
+		logger:  logger,
+		opMgr:   operation.NewSingleOperationManager(),
+		bus:     bus,
+		channel: newConf.Channel,
+	}
+
+	if newConf.Min > 0 {
+		theServo.min = uint32(newConf.Min)
+	}
+	if newConf.Max > 0 {
+		theServo.max = uint32(newConf.Max)
+	}
+	theServo.maxRotation = uint32(newConf.MaxRotation)
+	if theServo.maxRotation == 0 {
+		theServo.maxRotation = uint32(servoDefaultMaxRotation)
+	}
+
+	startAngle := 90
+	if newConf.StartPos != nil {
+		startAngle = int(*newConf.StartPos)
+	}
+	if err := theServo.writeAngle(startAngle); err != nil {
+		bus.release()
+		return nil, err
+	}
+
+	if newConf.HoldPos == nil || *newConf.HoldPos {
+		theServo.holdPos = true
+	} else {
+		theServo.holdPos = false
+		if err := theServo.bus.setChannelTicks(theServo.channel, 0, 0); err != nil {
+			bus.release()
+			return nil, err
+		}
+	}
+
+	return theServo, nil
+}
+
+// writeAngle translates angle to on/off tick counts and writes them to the channel.
+func (s *pca9685Servo) writeAngle(angle int) error {
+	pulseUs := angleToPulseWidth(angle, int(s.maxRotation))
+	offTicks := pca9685OffTicks(pulseUs, s.bus.freq)
+	if err := s.bus.setChannelTicks(s.channel, 0, offTicks); err != nil {
+		return err
+	}
+	s.pulseWidth = pulseUs
+	return nil
+}
+
+// pca9685OffTicks converts a pulse width in microseconds to the 12-bit off-tick count the
+// PCA9685 expects, given the chip's configured PWM frequency (4096 ticks per cycle).
+func pca9685OffTicks(pulseUs, freqHz int) uint16 {
+	return uint16(pulseUs * freqHz * pca9685TicksPerCycle / 1_000_000)
+}
+
+// Move moves the servo to the given angle (0-180 degrees).
+func (s *pca9685Servo) Move(ctx context.Context, angle uint32, extra map[string]interface{}) error {
+	ctx, done := s.opMgr.New(ctx)
+	defer done()
+
+	if s.min > 0 && angle < s.min {
+		angle = s.min
+	}
+	if s.max > 0 && angle > s.max {
+		angle = s.max
+	}
+
+	if err := s.writeAngle(int(angle)); err != nil {
+		return err
+	}
+
+	utils.SelectContextOrWait(ctx, time.Duration(s.pulseWidth)*time.Microsecond)
+
+	if !s.holdPos {
+		time.Sleep(time.Duration(holdTime))
+		return s.bus.setChannelTicks(s.channel, 0, 0)
+	}
+	return nil
+}
+
+// Position returns the current set angle (degrees) of the servo.
+func (s *pca9685Servo) Position(ctx context.Context, extra map[string]interface{}) (uint32, error) {
+	return uint32(pulseWidthToAngle(s.pulseWidth, int(s.maxRotation))), nil
+}
+
+// Stop stops the servo by turning off its PWM output entirely.
+func (s *pca9685Servo) Stop(ctx context.Context, extra map[string]interface{}) error {
+	_, done := s.opMgr.New(ctx)
+	defer done()
+	return s.bus.setChannelTicks(s.channel, 0, 0)
+}
+
+// IsMoving returns whether the servo is actively moving under its own power.
+func (s *pca9685Servo) IsMoving(ctx context.Context) (bool, error) {
+	return s.opMgr.OpRunning(), nil
+}
+
+// Close releases this servo's reference to the shared PCA9685 I2C bus.
+func (s *pca9685Servo) Close(_ context.Context) error {
+	s.bus.release()
+	return nil
+}
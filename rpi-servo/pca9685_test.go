@@ -0,0 +1,28 @@
+//go:build linux && (arm64 || arm) && !no_pigpio && !no_cgo
+
+package rpiservo
+
+import "testing"
+
+func TestPCA9685OffTicks(t *testing.T) {
+	cases := []struct {
+		name    string
+		pulseUs int
+		freqHz  int
+		want    uint16
+	}{
+		{"center pulse at 50Hz", 1500, 50, 307},
+		{"min pulse at 50Hz", 500, 50, 102},
+		{"max pulse at 50Hz", 2500, 50, 512},
+		{"center pulse at 60Hz", 1500, 60, 368},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := pca9685OffTicks(tc.pulseUs, tc.freqHz)
+			if got != tc.want {
+				t.Errorf("pca9685OffTicks(%d, %d) = %d, want %d", tc.pulseUs, tc.freqHz, got, tc.want)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import "C"
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	rpiutils "viamrpi/utils"
@@ -41,10 +42,12 @@ import (
 
 var Model = resource.NewModel("viam", "raspberry-pi", "rpi-servo")
 
-// Default configuration collected from data sheet
-var (
-	holdTime                = 250000000 // 250ms in nanoseconds
-	servoDefaultMaxRotation = 180
+// Motion profiles supported by ServoConfig.Profile.
+const (
+	profileInstant     = "instant"
+	profileLinear      = "linear"
+	profileTrapezoidal = "trapezoidal"
+	moveTickInterval   = 20 * time.Millisecond
 )
 
 // init registers a pi servo based on pigpio.
@@ -78,6 +81,12 @@ func (s *piPigpioServo) validateAndSetConfiguration(conf *ServoConfig) error {
 	}
 
 	s.pinname = conf.Pin
+	s.maxSpeedDegPerSec = conf.MaxSpeedDegPerSec
+	s.accelDegPerSec2 = conf.AccelDegPerSec2
+	s.profile = conf.Profile
+	if s.profile == "" {
+		s.profile = profileInstant
+	}
 
 	return nil
 }
@@ -175,12 +184,14 @@ func setInitialPosition(theServo *piPigpioServo, newConf *ServoConfig) error {
 	if newConf.StartPos == nil {
 		// Set the servo to the default 90 degrees position
 		setPos = C.set_servo_pulsewidth(theServo.piID, theServo.pin, C.uint(1500))
+		theServo.currentAngle = 90
 	} else {
 		// Set the servo to the specified start position
 		setPos = C.set_servo_pulsewidth(
 			theServo.piID, theServo.pin,
 			C.uint(angleToPulseWidth(int(*newConf.StartPos), int(theServo.maxRotation))),
 		)
+		theServo.currentAngle = uint32(*newConf.StartPos)
 	}
 	errorCode := int(setPos)
 	if errorCode != 0 {
@@ -216,10 +227,19 @@ type piPigpioServo struct {
 	holdPos     bool
 	maxRotation uint32
 	piID        C.int
+
+	maxSpeedDegPerSec float64 // 0 disables motion profiling: Move writes the pulse width directly
+	accelDegPerSec2   float64 // used by the "trapezoidal" profile
+	profile           string
+	currentAngle      uint32 // last commanded intermediate angle, for Position
 }
 
-// Move moves the servo to the given angle (0-180 degrees)
-// This will block until done or a new operation cancels this one
+// Move moves the servo to the given angle (0-180 degrees).
+// With no MaxSpeedDegPerSec configured, or with Profile "instant", this issues
+// a single pulse-width write and blocks until done, as before. With
+// MaxSpeedDegPerSec set and Profile "linear" or "trapezoidal", it instead
+// ramps toward the target angle according to Profile, ticking every
+// moveTickInterval; a new Move preempts an in-flight ramp via s.opMgr.
 func (s *piPigpioServo) Move(ctx context.Context, angle uint32, extra map[string]interface{}) error {
 	ctx, done := s.opMgr.New(ctx)
 	defer done()
@@ -230,18 +250,116 @@ func (s *piPigpioServo) Move(ctx context.Context, angle uint32, extra map[string
 	if s.max > 0 && angle > s.max {
 		angle = s.max
 	}
+
+	if s.maxSpeedDegPerSec <= 0 || s.profile == profileInstant {
+		return s.moveInstant(ctx, angle)
+	}
+	return s.moveProfiled(ctx, angle)
+}
+
+// moveInstant is the original behavior: write the target pulse width once and block for its duration.
+func (s *piPigpioServo) moveInstant(ctx context.Context, angle uint32) error {
 	pulseWidth := angleToPulseWidth(int(angle), int(s.maxRotation))
 	res := C.set_servo_pulsewidth(s.piID, s.pin, C.uint(pulseWidth))
 
 	s.pulseWidth = pulseWidth
+	s.currentAngle = angle
 
 	if res != 0 {
-		err := s.pigpioErrors(int(res))
-		return err
+		return s.pigpioErrors(int(res))
 	}
 
 	utils.SelectContextOrWait(ctx, time.Duration(pulseWidth)*time.Microsecond) // duration of pulswidth send on pin and servo moves
 
+	return s.releaseIfNotHolding()
+}
+
+// moveProfiled interpolates from the current angle to the target over time, writing an
+// intermediate pulse width every moveTickInterval until the target is reached or ctx is canceled.
+func (s *piPigpioServo) moveProfiled(ctx context.Context, target uint32) error {
+	start := s.currentAngle
+	if start == target {
+		return nil
+	}
+
+	ticker := time.NewTicker(moveTickInterval)
+	defer ticker.Stop()
+
+	dt := moveTickInterval.Seconds()
+	traveled := 0.0
+	speed := 0.0
+	direction := 1.0
+	if target < start {
+		direction = -1
+	}
+	totalDeg := math.Abs(float64(target) - float64(start))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		switch s.profile {
+		case profileTrapezoidal:
+			speed = trapezoidalSpeed(traveled, totalDeg, s.maxSpeedDegPerSec, s.accelDegPerSec2, speed, dt)
+		default: // linear
+			speed = s.maxSpeedDegPerSec
+		}
+
+		traveled += speed * dt
+		done := traveled >= totalDeg
+		if done {
+			traveled = totalDeg
+		}
+
+		angle := computeRampAngle(start, direction, traveled)
+		pulseWidth := angleToPulseWidth(int(angle), int(s.maxRotation))
+		res := C.set_servo_pulsewidth(s.piID, s.pin, C.uint(pulseWidth))
+		s.pulseWidth = pulseWidth
+		s.currentAngle = angle
+		if res != 0 {
+			return s.pigpioErrors(int(res))
+		}
+
+		if done {
+			return s.releaseIfNotHolding()
+		}
+	}
+}
+
+// computeRampAngle computes the intermediate angle a distance traveled into a
+// ramp from start toward target. Converting through float64 matters here:
+// direction is -1 for descending ramps, so direction*traveled is negative,
+// and uint32(<negative float>) is implementation-defined in Go, whereas
+// adding it to start in float64 first always rounds toward the correct angle.
+func computeRampAngle(start uint32, direction, traveled float64) uint32 {
+	return uint32(float64(start) + direction*traveled)
+}
+
+// trapezoidalSpeed computes the next tick's speed (deg/sec) for an accel/cruise/decel profile.
+func trapezoidalSpeed(traveled, totalDeg, maxSpeed, accel, currentSpeed, dt float64) float64 {
+	if accel <= 0 {
+		return maxSpeed
+	}
+	decelDist := (currentSpeed * currentSpeed) / (2 * accel)
+	if totalDeg-traveled <= decelDist {
+		speed := currentSpeed - accel*dt
+		if speed < 0 {
+			speed = 0
+		}
+		return speed
+	}
+	speed := currentSpeed + accel*dt
+	if speed > maxSpeed {
+		speed = maxSpeed
+	}
+	return speed
+}
+
+// releaseIfNotHolding disables the servo once it has reached a position, if HoldPos is false.
+func (s *piPigpioServo) releaseIfNotHolding() error {
 	if !s.holdPos { // the following logic disables a servo once it has reached a position or after a certain amount of time has been reached
 		time.Sleep(time.Duration(holdTime)) // time before a stop is sent
 		setPos := C.set_servo_pulsewidth(s.piID, s.pin, C.uint(0))
@@ -269,8 +387,13 @@ func (s *piPigpioServo) pigpioErrors(res int) error {
 	}
 }
 
-// Position returns the current set angle (degrees) of the servo.
+// Position returns the current set angle (degrees) of the servo. When motion
+// profiling is active this is the last commanded intermediate angle rather
+// than the final target, since Move may still be ramping toward it.
 func (s *piPigpioServo) Position(ctx context.Context, extra map[string]interface{}) (uint32, error) {
+	if s.maxSpeedDegPerSec > 0 && s.profile != profileInstant {
+		return s.currentAngle, nil
+	}
 	res := C.get_servo_pulsewidth(s.piID, s.pin)
 	err := s.pigpioErrors(int(res))
 	if int(res) != 0 {
@@ -282,20 +405,6 @@ func (s *piPigpioServo) Position(ctx context.Context, extra map[string]interface
 	return uint32(pulseWidthToAngle(int(s.res), int(s.maxRotation))), nil
 }
 
-// angleToPulseWidth changes the input angle in degrees
-// into the corresponding pulsewidth value in microsecond
-func angleToPulseWidth(angle, maxRotation int) int {
-	pulseWidth := 500 + (2000 * angle / maxRotation)
-	return pulseWidth
-}
-
-// pulseWidthToAngle changes the pulsewidth value in microsecond
-// to the corresponding angle in degrees
-func pulseWidthToAngle(pulseWidth, maxRotation int) int {
-	angle := maxRotation * (pulseWidth + 1 - 500) / 2000
-	return angle
-}
-
 // Stop stops the servo. It is assumed the servo stops immediately.
 func (s *piPigpioServo) Stop(ctx context.Context, extra map[string]interface{}) error {
 	_, done := s.opMgr.New(ctx)
@@ -0,0 +1,29 @@
+//go:build linux && (arm64 || arm) && !no_pigpio && !no_cgo
+
+package rpiservo
+
+import "testing"
+
+func TestComputeRampAngle(t *testing.T) {
+	cases := []struct {
+		name      string
+		start     uint32
+		direction float64
+		traveled  float64
+		want      uint32
+	}{
+		{"ascending ramp midpoint", 10, 1, 20, 30},
+		{"ascending ramp start", 10, 1, 0, 10},
+		{"descending ramp midpoint", 90, -1, 30, 60},
+		{"descending ramp reaches zero", 90, -1, 90, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := computeRampAngle(tc.start, tc.direction, tc.traveled)
+			if got != tc.want {
+				t.Errorf("computeRampAngle(%d, %v, %v) = %d, want %d", tc.start, tc.direction, tc.traveled, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+//go:build linux
+
+package rpiservo
+
+// Default configuration collected from data sheet, shared by every rpiservo
+// backend regardless of whether it talks to pigpio, a PCA9685, or sysfs PWM.
+var (
+	holdTime                = 250000000 // 250ms in nanoseconds
+	servoDefaultMaxRotation = 180
+)
+
+// angleToPulseWidth changes the input angle in degrees
+// into the corresponding pulsewidth value in microsecond
+func angleToPulseWidth(angle, maxRotation int) int {
+	pulseWidth := 500 + (2000 * angle / maxRotation)
+	return pulseWidth
+}
+
+// pulseWidthToAngle changes the pulsewidth value in microsecond
+// to the corresponding angle in degrees
+func pulseWidthToAngle(pulseWidth, maxRotation int) int {
+	angle := maxRotation * (pulseWidth + 1 - 500) / 2000
+	return angle
+}
@@ -0,0 +1,414 @@
+//go:build linux && (arm64 || arm) && !no_pigpio && !no_cgo
+
+package rpiservo
+
+/*
+	This driver implements a software (ISR-style) servo backend that
+	multiplexes many servos across arbitrary GPIO pins using a single
+	goroutine driving a 20ms frame, in the spirit of interrupt-driven
+	Arduino/RP2040 servo libraries. It trades the precision of pigpio's
+	DMA-based PWM for the ability to drive servos on pins pigpio would
+	otherwise refuse to hand out PWM slots for.
+*/
+
+// #include <stdlib.h>
+// #include <pigpiod_if2.h>
+// #cgo LDFLAGS: -lpigpio
+// #include "../rpi/pi.h"
+import "C"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	rpiutils "viamrpi/utils"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/servo"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
+	"go.viam.com/rdk/resource"
+)
+
+// SoftwareServoModel is a software-multiplexed servo driven by a shared
+// frame scheduler instead of pigpio's DMA-based PWM.
+var SoftwareServoModel = resource.NewModel("viam", "raspberry-pi", "software-servo")
+
+const softwareServoFrame = 20 * time.Millisecond
+
+// SoftwareServoConfig is the configuration for a single channel of the
+// software servo scheduler.
+type SoftwareServoConfig struct {
+	Pin           string   `json:"pin"`
+	Min           int      `json:"min,omitempty"`
+	Max           int      `json:"max,omitempty"`
+	MinPulseUs    int      `json:"min_pulse_us,omitempty"`
+	MaxPulseUs    int      `json:"max_pulse_us,omitempty"`
+	MaxRotation   int      `json:"max_rotation_deg,omitempty"`
+	StartPos      *float64 `json:"starting_position_degs,omitempty"`
+	HoldPos       *bool    `json:"hold_position,omitempty"`
+	SlewDegPerSec float64  `json:"slew_deg_per_sec,omitempty"`
+}
+
+// Validate validates the config and returns implicit dependencies.
+func (conf *SoftwareServoConfig) Validate(path string) ([]string, error) {
+	if conf.Pin == "" {
+		return nil, resource.NewConfigValidationError(path, errPinRequired)
+	}
+	return []string{}, nil
+}
+
+func init() {
+	resource.RegisterComponent(
+		servo.API,
+		SoftwareServoModel,
+		resource.Registration[servo.Servo, *SoftwareServoConfig]{
+			Constructor: newSoftwareServo,
+		},
+	)
+}
+
+// softwareServoChannel is one GPIO pin's state as seen by the scheduler.
+type softwareServoChannel struct {
+	pin           C.uint
+	minPulseUs    int
+	maxPulseUs    int
+	maxRotation   int
+	minAngle      uint32 // 0 means unclamped
+	maxAngle      uint32 // 0 means unclamped
+	slewDegPerSec float64
+
+	targetPulseUs  atomic.Int64
+	currentPulseUs atomic.Int64
+	holdPos        atomic.Bool
+	releaseGen     atomic.Int64
+}
+
+// softwareServoScheduler drives every registered channel from a single
+// goroutine, raising all pins at the start of each 20ms frame
+// and lowering each one at its scheduled pulse-end time.
+type softwareServoScheduler struct {
+	piID C.int
+
+	mu       sync.Mutex
+	channels map[uint]*softwareServoChannel
+
+	startOnce sync.Once
+	stop      chan struct{}
+}
+
+// There is a single software servo scheduler per process: it owns one
+// pigpio daemon connection and one frame goroutine that every software servo
+// channel, on every pin, shares. This is what makes DoCommand's bulk set
+// atomic within one 20ms frame across the whole fleet of software servos.
+var (
+	softwareServoSchedulerMu sync.Mutex
+	softwareServoSharedSched *softwareServoScheduler
+	softwareServoRefs        int
+)
+
+func acquireSoftwareServoScheduler() *softwareServoScheduler {
+	softwareServoSchedulerMu.Lock()
+	defer softwareServoSchedulerMu.Unlock()
+
+	if softwareServoSharedSched == nil {
+		sched := &softwareServoScheduler{
+			piID:     C.custom_pigpio_start(),
+			channels: map[uint]*softwareServoChannel{},
+			stop:     make(chan struct{}),
+		}
+		sched.startOnce.Do(sched.run)
+		softwareServoSharedSched = sched
+	}
+	softwareServoRefs++
+	return softwareServoSharedSched
+}
+
+// releaseSoftwareServoScheduler drops a reference to the process-wide
+// scheduler, stopping its frame goroutine and pigpio connection once the
+// last software servo channel has been closed.
+func releaseSoftwareServoScheduler() {
+	softwareServoSchedulerMu.Lock()
+	defer softwareServoSchedulerMu.Unlock()
+
+	softwareServoRefs--
+	if softwareServoRefs > 0 {
+		return
+	}
+	sched := softwareServoSharedSched
+	softwareServoSharedSched = nil
+	close(sched.stop)
+	C.pigpio_stop(sched.piID)
+}
+
+// addChannel registers a pin with the scheduler, enabling output on it.
+func (sched *softwareServoScheduler) addChannel(bcom uint, ch *softwareServoChannel) {
+	sched.mu.Lock()
+	defer sched.mu.Unlock()
+	C.set_mode(sched.piID, ch.pin, C.PI_OUTPUT)
+	sched.channels[bcom] = ch
+}
+
+func (sched *softwareServoScheduler) removeChannel(bcom uint) {
+	sched.mu.Lock()
+	defer sched.mu.Unlock()
+	delete(sched.channels, bcom)
+}
+
+// pulseEnd is a single (pin, offset) entry in a frame's lowering schedule.
+type pulseEnd struct {
+	pin    C.uint
+	offset time.Duration
+}
+
+// run is the scheduler's single long-lived goroutine: one 20ms frame at a
+// time, it raises every channel's pin, then lowers each one at its
+// individually scheduled offset using a ~1us-resolution ticker.
+func (sched *softwareServoScheduler) run() {
+	go func() {
+		ticker := time.NewTicker(softwareServoFrame)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-sched.stop:
+				return
+			case frameStart := <-ticker.C:
+				sched.runFrame(frameStart)
+			}
+		}
+	}()
+}
+
+func (sched *softwareServoScheduler) runFrame(frameStart time.Time) {
+	sched.mu.Lock()
+	schedule := make([]pulseEnd, 0, len(sched.channels))
+	for _, ch := range sched.channels {
+		target := ch.targetPulseUs.Load()
+		current := ch.currentPulseUs.Load()
+		if ch.slewDegPerSec > 0 && target > 0 && current > 0 && target != current {
+			current = slewStep(current, target, ch.slewDegPerSec, ch.minPulseUs, ch.maxPulseUs, ch.maxRotation)
+		} else {
+			current = target
+		}
+		ch.currentPulseUs.Store(current)
+
+		if current <= 0 {
+			continue
+		}
+		C.gpio_write(sched.piID, ch.pin, 1)
+		schedule = append(schedule, pulseEnd{pin: ch.pin, offset: time.Duration(current) * time.Microsecond})
+	}
+	sched.mu.Unlock()
+
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].offset < schedule[j].offset })
+
+	ticker := time.NewTicker(time.Microsecond)
+	defer ticker.Stop()
+	idx := 0
+	for idx < len(schedule) {
+		now := <-ticker.C
+		elapsed := now.Sub(frameStart)
+		for idx < len(schedule) && schedule[idx].offset <= elapsed {
+			C.gpio_write(sched.piID, schedule[idx].pin, 0)
+			idx++
+		}
+	}
+}
+
+// pulseUsForAngle clamps angle to the channel's configured min/max angle and
+// converts it to a pulse width in microseconds.
+func pulseUsForAngle(ch *softwareServoChannel, angle uint32) int64 {
+	if ch.minAngle > 0 && angle < ch.minAngle {
+		angle = ch.minAngle
+	}
+	if ch.maxAngle > 0 && angle > ch.maxAngle {
+		angle = ch.maxAngle
+	}
+	return int64(ch.minPulseUs + (ch.maxPulseUs-ch.minPulseUs)*int(angle)/ch.maxRotation)
+}
+
+// slewStep advances current toward target by at most the distance covered in
+// one frame at the configured slew rate.
+func slewStep(current, target int64, degPerSec float64, minUs, maxUs, maxRotation int) int64 {
+	usPerDeg := float64(maxUs-minUs) / float64(maxRotation)
+	maxStepUs := int64(degPerSec * usPerDeg * softwareServoFrame.Seconds())
+	if maxStepUs <= 0 {
+		return target
+	}
+	if target > current {
+		if target-current > maxStepUs {
+			return current + maxStepUs
+		}
+		return target
+	}
+	if current-target > maxStepUs {
+		return current - maxStepUs
+	}
+	return target
+}
+
+// softwareServo implements servo.Servo for a single channel of the shared
+// softwareServoScheduler. Move is non-blocking: it only updates the channel's
+// atomic target pulse width, which the scheduler goroutine converges toward.
+type softwareServo struct {
+	resource.Named
+	resource.AlwaysRebuild
+	logger logging.Logger
+	opMgr  *operation.SingleOperationManager
+
+	sched   *softwareServoScheduler
+	bcom    uint
+	channel *softwareServoChannel
+}
+
+func newSoftwareServo(
+	ctx context.Context,
+	_ resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (servo.Servo, error) {
+	newConf, err := resource.NativeConfig[*SoftwareServoConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+
+	bcom, have := rpiutils.BroadcomPinFromHardwareLabel(newConf.Pin)
+	if !have {
+		return nil, errors.Errorf("no hw mapping for %s", newConf.Pin)
+	}
+
+	minPulseUs, maxPulseUs := 500, 2500
+	if newConf.MinPulseUs > 0 {
+		minPulseUs = newConf.MinPulseUs
+	}
+	if newConf.MaxPulseUs > 0 {
+		maxPulseUs = newConf.MaxPulseUs
+	}
+	maxRotation := newConf.MaxRotation
+	if maxRotation == 0 {
+		maxRotation = servoDefaultMaxRotation
+	}
+
+	sched := acquireSoftwareServoScheduler()
+
+	ch := &softwareServoChannel{
+		pin:           C.uint(bcom),
+		minPulseUs:    minPulseUs,
+		maxPulseUs:    maxPulseUs,
+		maxRotation:   maxRotation,
+		minAngle:      uint32(newConf.Min),
+		maxAngle:      uint32(newConf.Max),
+		slewDegPerSec: newConf.SlewDegPerSec,
+	}
+	ch.holdPos.Store(newConf.HoldPos == nil || *newConf.HoldPos)
+
+	startAngle := 90
+	if newConf.StartPos != nil {
+		startAngle = int(*newConf.StartPos)
+	}
+	startPulseUs := int64(minPulseUs + (maxPulseUs-minPulseUs)*startAngle/maxRotation)
+	ch.targetPulseUs.Store(startPulseUs)
+	ch.currentPulseUs.Store(startPulseUs)
+
+	sched.addChannel(bcom, ch)
+
+	theServo := &softwareServo{
+		Named:   conf.ResourceName().AsNamed(),
+		logger:  logger,
+		opMgr:   operation.NewSingleOperationManager(),
+		sched:   sched,
+		bcom:    bcom,
+		channel: ch,
+	}
+
+	return theServo, nil
+}
+
+// Move sets this channel's target pulse width; the scheduler goroutine
+// converges toward it on subsequent frames, respecting any configured
+// slew-rate limit. It does not block for the pulse to be reached.
+func (s *softwareServo) Move(ctx context.Context, angle uint32, extra map[string]interface{}) error {
+	_, done := s.opMgr.New(ctx)
+	defer done()
+
+	ch := s.channel
+	ch.targetPulseUs.Store(pulseUsForAngle(ch, angle))
+	if !ch.holdPos.Load() {
+		gen := ch.releaseGen.Add(1)
+		go func() {
+			time.Sleep(time.Duration(holdTime))
+			// only release if no later Move has superseded this one
+			if ch.releaseGen.Load() == gen {
+				ch.targetPulseUs.Store(0)
+			}
+		}()
+	}
+	return nil
+}
+
+// Position returns the angle corresponding to the pulse width the scheduler is
+// currently emitting on this channel. While a slew is in progress this lags the
+// target set by Move, and once the channel is stopped or released it reads 0.
+func (s *softwareServo) Position(ctx context.Context, extra map[string]interface{}) (uint32, error) {
+	ch := s.channel
+	pulseUs := ch.currentPulseUs.Load()
+	angle := int64(ch.maxRotation) * (pulseUs - int64(ch.minPulseUs)) / int64(ch.maxPulseUs-ch.minPulseUs)
+	if angle < 0 {
+		angle = 0
+	}
+	return uint32(angle), nil
+}
+
+// Stop immediately stops emitting pulses on this channel.
+func (s *softwareServo) Stop(ctx context.Context, extra map[string]interface{}) error {
+	_, done := s.opMgr.New(ctx)
+	defer done()
+	s.channel.targetPulseUs.Store(0)
+	return nil
+}
+
+// IsMoving returns whether the channel's current pulse has not yet converged to its target.
+func (s *softwareServo) IsMoving(ctx context.Context) (bool, error) {
+	ch := s.channel
+	return ch.currentPulseUs.Load() != ch.targetPulseUs.Load(), nil
+}
+
+// DoCommand supports bulk-setting many channels on the same scheduler atomically within one frame.
+// The "angles" key maps hardware pin labels to target angles in degrees.
+func (s *softwareServo) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	anglesRaw, ok := cmd["angles"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("DoCommand requires an \"angles\" map of pin label to angle")
+	}
+
+	s.sched.mu.Lock()
+	defer s.sched.mu.Unlock()
+	for pin, raw := range anglesRaw {
+		angle, ok := raw.(float64)
+		if !ok {
+			return nil, errors.Errorf("angle for pin %s must be a number", pin)
+		}
+		bcom, have := rpiutils.BroadcomPinFromHardwareLabel(pin)
+		if !have {
+			return nil, errors.Errorf("no hw mapping for %s", pin)
+		}
+		ch, ok := s.sched.channels[bcom]
+		if !ok {
+			return nil, errors.Errorf("no software servo configured on pin %s", pin)
+		}
+		ch.targetPulseUs.Store(pulseUsForAngle(ch, uint32(angle)))
+	}
+	return map[string]interface{}{}, nil
+}
+
+// Close removes this channel from the shared scheduler.
+func (s *softwareServo) Close(_ context.Context) error {
+	s.sched.removeChannel(s.bcom)
+	releaseSoftwareServoScheduler()
+	return nil
+}
@@ -0,0 +1,58 @@
+//go:build linux && (arm64 || arm) && !no_pigpio && !no_cgo
+
+package rpiservo
+
+import "testing"
+
+func TestPulseUsForAngle(t *testing.T) {
+	ch := &softwareServoChannel{minPulseUs: 500, maxPulseUs: 2500, maxRotation: 180}
+
+	cases := []struct {
+		name  string
+		ch    *softwareServoChannel
+		angle uint32
+		want  int64
+	}{
+		{"midpoint", ch, 90, 1500},
+		{"min", ch, 0, 500},
+		{"max", ch, 180, 2500},
+		{"clamped to configured min angle", &softwareServoChannel{minPulseUs: 500, maxPulseUs: 2500, maxRotation: 180, minAngle: 45}, 0, 1000},
+		{"clamped to configured max angle", &softwareServoChannel{minPulseUs: 500, maxPulseUs: 2500, maxRotation: 180, maxAngle: 135}, 180, 2000},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := pulseUsForAngle(tc.ch, tc.angle)
+			if got != tc.want {
+				t.Errorf("pulseUsForAngle(%+v, %d) = %d, want %d", tc.ch, tc.angle, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSlewStep(t *testing.T) {
+	cases := []struct {
+		name        string
+		current     int64
+		target      int64
+		degPerSec   float64
+		minUs       int
+		maxUs       int
+		maxRotation int
+		want        int64
+	}{
+		{"no slew limit reaches target immediately", 500, 2500, 0, 500, 2500, 180, 2500},
+		{"small step toward target is capped", 500, 2500, 90, 500, 2500, 180, 500 + int64(90*(2000.0/180)*softwareServoFrame.Seconds())},
+		{"step overshooting target lands on target", 2490, 2500, 90, 500, 2500, 180, 2500},
+		{"descending step is capped", 2500, 500, 90, 500, 2500, 180, 2500 - int64(90*(2000.0/180)*softwareServoFrame.Seconds())},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := slewStep(tc.current, tc.target, tc.degPerSec, tc.minUs, tc.maxUs, tc.maxRotation)
+			if got != tc.want {
+				t.Errorf("slewStep(%d, %d, %v, ...) = %d, want %d", tc.current, tc.target, tc.degPerSec, got, tc.want)
+			}
+		})
+	}
+}
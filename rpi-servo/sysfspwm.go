@@ -0,0 +1,233 @@
+//go:build linux && !no_sysfs_pwm
+
+package rpiservo
+
+/*
+	This driver implements servo.Servo on top of the Linux kernel's sysfs PWM
+	interface (/sys/class/pwm/pwmchipN/pwmM/), rather than pigpio. It lets
+	this package drive servos on boards where pigpio isn't available (e.g.
+	Raspberry Pi 5) or on non-Pi, BeagleBone-class boards with hardware PWM
+	pins, at the cost of only supporting the handful of pins with a PWM
+	hardware block instead of any GPIO.
+*/
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/servo"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
+	"go.viam.com/rdk/resource"
+)
+
+// SysfsPWMModel is a servo driven through the kernel's sysfs PWM interface.
+var SysfsPWMModel = resource.NewModel("viam", "raspberry-pi", "sysfs-pwm-servo")
+
+const sysfsPWMPeriodNs = 20_000_000 // 20ms, standard hobby servo frame
+
+// SysfsPWMServoConfig is the configuration for a servo driven through the
+// kernel's sysfs PWM interface, sharing ServoConfig's shape aside from
+// addressing a PWM chip/channel instead of a pigpio GPIO pin.
+type SysfsPWMServoConfig struct {
+	PWMChip     int      `json:"pwm_chip"`
+	PWMChannel  int      `json:"pwm_channel"`
+	Min         int      `json:"min,omitempty"`
+	Max         int      `json:"max,omitempty"`
+	StartPos    *float64 `json:"starting_position_degs,omitempty"`
+	HoldPos     *bool    `json:"hold_position,omitempty"`
+	MaxRotation int      `json:"max_rotation_deg,omitempty"`
+	Invert      bool     `json:"invert,omitempty"`
+}
+
+// Validate validates the config and returns implicit dependencies.
+func (conf *SysfsPWMServoConfig) Validate(path string) ([]string, error) {
+	if conf.PWMChip < 0 || conf.PWMChannel < 0 {
+		return nil, resource.NewConfigValidationError(path, errors.New("pwm_chip and pwm_channel must not be negative"))
+	}
+	return []string{}, nil
+}
+
+func init() {
+	resource.RegisterComponent(
+		servo.API,
+		SysfsPWMModel,
+		resource.Registration[servo.Servo, *SysfsPWMServoConfig]{
+			Constructor: newSysfsPWMServo,
+		},
+	)
+}
+
+// sysfsPWMServo implements servo.Servo on top of /sys/class/pwm/pwmchipN/pwmM/.
+type sysfsPWMServo struct {
+	resource.Named
+	resource.AlwaysRebuild
+	logger logging.Logger
+	opMgr  *operation.SingleOperationManager
+
+	pwmDir      string
+	min, max    uint32
+	maxRotation uint32
+	holdPos     bool
+	invert      bool
+	pulseWidth  int
+}
+
+func newSysfsPWMServo(
+	ctx context.Context,
+	_ resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (servo.Servo, error) {
+	newConf, err := resource.NativeConfig[*SysfsPWMServoConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+
+	chipDir := fmt.Sprintf("/sys/class/pwm/pwmchip%d", newConf.PWMChip)
+	pwmDir := filepath.Join(chipDir, fmt.Sprintf("pwm%d", newConf.PWMChannel))
+
+	if _, err := os.Stat(pwmDir); os.IsNotExist(err) {
+		if err := writeSysfsFile(filepath.Join(chipDir, "export"), strconv.Itoa(newConf.PWMChannel)); err != nil {
+			return nil, errors.Wrapf(err, "failed to export pwmchip%d/pwm%d", newConf.PWMChip, newConf.PWMChannel)
+		}
+	}
+
+	theServo := &sysfsPWMServo{
+		Named:  conf.ResourceName().AsNamed(),
+		logger: logger,
+		opMgr:  operation.NewSingleOperationManager(),
+		pwmDir: pwmDir,
+		invert: newConf.Invert,
+	}
+
+	if newConf.Min > 0 {
+		theServo.min = uint32(newConf.Min)
+	}
+	if newConf.Max > 0 {
+		theServo.max = uint32(newConf.Max)
+	}
+	theServo.maxRotation = uint32(newConf.MaxRotation)
+	if theServo.maxRotation == 0 {
+		theServo.maxRotation = uint32(servoDefaultMaxRotation)
+	}
+
+	if err := theServo.writePolarity(); err != nil {
+		return nil, err
+	}
+	if err := writeSysfsFile(filepath.Join(pwmDir, "period"), strconv.Itoa(sysfsPWMPeriodNs)); err != nil {
+		return nil, errors.Wrap(err, "failed to set pwm period")
+	}
+
+	startAngle := 90
+	if newConf.StartPos != nil {
+		startAngle = int(*newConf.StartPos)
+	}
+	if err := theServo.writeAngle(startAngle); err != nil {
+		return nil, err
+	}
+	if err := writeSysfsFile(filepath.Join(pwmDir, "enable"), "1"); err != nil {
+		return nil, errors.Wrap(err, "failed to enable pwm")
+	}
+
+	if newConf.HoldPos == nil || *newConf.HoldPos {
+		theServo.holdPos = true
+	} else {
+		theServo.holdPos = false
+		if err := writeSysfsFile(filepath.Join(pwmDir, "enable"), "0"); err != nil {
+			return nil, err
+		}
+	}
+
+	return theServo, nil
+}
+
+// writePolarity sets the PWM channel's polarity based on the Invert config option.
+func (s *sysfsPWMServo) writePolarity() error {
+	polarity := "normal"
+	if s.invert {
+		polarity = "inversed"
+	}
+	return writeSysfsFile(filepath.Join(s.pwmDir, "polarity"), polarity)
+}
+
+// writeAngle translates angle to a duty cycle and writes it to the PWM channel.
+func (s *sysfsPWMServo) writeAngle(angle int) error {
+	pulseUs := angleToPulseWidth(angle, int(s.maxRotation))
+	dutyCycleNs := sysfsDutyCycleNs(pulseUs)
+	if err := writeSysfsFile(filepath.Join(s.pwmDir, "duty_cycle"), strconv.Itoa(dutyCycleNs)); err != nil {
+		return errors.Wrap(err, "failed to set pwm duty cycle")
+	}
+	s.pulseWidth = pulseUs
+	return nil
+}
+
+// sysfsDutyCycleNs converts a pulse width in microseconds to the nanosecond duty cycle the
+// sysfs PWM "duty_cycle" attribute expects.
+func sysfsDutyCycleNs(pulseUs int) int {
+	return pulseUs * 1000
+}
+
+// Move moves the servo to the given angle (0-180 degrees).
+func (s *sysfsPWMServo) Move(ctx context.Context, angle uint32, extra map[string]interface{}) error {
+	ctx, done := s.opMgr.New(ctx)
+	defer done()
+
+	if s.min > 0 && angle < s.min {
+		angle = s.min
+	}
+	if s.max > 0 && angle > s.max {
+		angle = s.max
+	}
+
+	if err := s.writeAngle(int(angle)); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(time.Duration(s.pulseWidth) * time.Microsecond):
+	}
+
+	if !s.holdPos {
+		time.Sleep(time.Duration(holdTime))
+		return writeSysfsFile(filepath.Join(s.pwmDir, "enable"), "0")
+	}
+	return nil
+}
+
+// Position returns the current set angle (degrees) of the servo.
+func (s *sysfsPWMServo) Position(ctx context.Context, extra map[string]interface{}) (uint32, error) {
+	return uint32(pulseWidthToAngle(s.pulseWidth, int(s.maxRotation))), nil
+}
+
+// Stop disables the PWM output, releasing the servo.
+func (s *sysfsPWMServo) Stop(ctx context.Context, extra map[string]interface{}) error {
+	_, done := s.opMgr.New(ctx)
+	defer done()
+	return writeSysfsFile(filepath.Join(s.pwmDir, "enable"), "0")
+}
+
+// IsMoving returns whether the servo is actively moving under its own power.
+func (s *sysfsPWMServo) IsMoving(ctx context.Context) (bool, error) {
+	return s.opMgr.OpRunning(), nil
+}
+
+// Close disables the PWM output. It does not unexport the channel, since
+// other processes may be sharing the pwmchip.
+func (s *sysfsPWMServo) Close(_ context.Context) error {
+	return writeSysfsFile(filepath.Join(s.pwmDir, "enable"), "0")
+}
+
+// writeSysfsFile writes a single sysfs attribute, as used throughout this driver for period,
+// duty_cycle, enable, and polarity.
+func writeSysfsFile(path, value string) error {
+	return os.WriteFile(path, []byte(value), 0o644)
+}
@@ -0,0 +1,26 @@
+//go:build linux && !no_sysfs_pwm
+
+package rpiservo
+
+import "testing"
+
+func TestSysfsDutyCycleNs(t *testing.T) {
+	cases := []struct {
+		name    string
+		pulseUs int
+		want    int
+	}{
+		{"min pulse", 500, 500_000},
+		{"center pulse", 1500, 1_500_000},
+		{"max pulse", 2500, 2_500_000},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sysfsDutyCycleNs(tc.pulseUs)
+			if got != tc.want {
+				t.Errorf("sysfsDutyCycleNs(%d) = %d, want %d", tc.pulseUs, got, tc.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,9 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	rpiutils "viamrpi/utils"
 
@@ -25,6 +28,86 @@ import (
 type RpiInterrupt struct {
 	interrupt  rpiutils.ReconfigurableDigitalInterrupt
 	callbackID C.uint // callback ID to close pi callback connection
+
+	// glitchFilterMicros and the noiseFilter* fields record whatever filters
+	// were applied at setup time, so teardown knows whether there's
+	// anything to reset on this gpio.
+	glitchFilterMicros      int
+	noiseFilterSteadyMicros int
+	noiseFilterActiveMicros int
+
+	// The following track measured pulse widths for ServoDigitalInterrupts,
+	// e.g. RC receiver channels or feedback from servos that report position
+	// via pulse width. They are unused by BasicDigitalInterrupts.
+	lastRiseNs    uint64
+	pulseWidthNs  atomic.Uint64
+	pulseMeasured atomic.Bool // true once a falling edge has produced a pulse width, even a zero one
+	pulseSubsMu   sync.Mutex
+	pulseSubs     []chan PulseEvent
+}
+
+// PulseEvent is a single measured high pulse on a ServoDigitalInterrupt pin.
+type PulseEvent struct {
+	PulseWidth time.Duration
+	Timestamp  time.Duration // nanoseconds since the pigpio daemon started, per pigpio's tick
+}
+
+// recordEdge updates the rising-edge timestamp on a rising edge, or computes
+// and publishes the pulse width on a falling edge.
+func (i *RpiInterrupt) recordEdge(high bool, nanoseconds uint64) {
+	if high {
+		i.lastRiseNs = nanoseconds
+		return
+	}
+	if i.lastRiseNs == 0 {
+		return
+	}
+	width := nanoseconds - i.lastRiseNs
+	i.pulseWidthNs.Store(width)
+	i.pulseMeasured.Store(true)
+
+	i.pulseSubsMu.Lock()
+	defer i.pulseSubsMu.Unlock()
+	event := PulseEvent{PulseWidth: time.Duration(width), Timestamp: time.Duration(nanoseconds)}
+	for _, ch := range i.pulseSubs {
+		select {
+		case ch <- event:
+		default: // drop the event rather than block the callback goroutine
+		}
+	}
+}
+
+// PulseWidth returns the most recently measured pulse width and whether one has been recorded yet.
+// A pulse width of exactly 0 is a legitimate measurement, so "recorded yet" is tracked separately
+// rather than inferred from the width being non-zero.
+func (i *RpiInterrupt) PulseWidth() (time.Duration, bool) {
+	width := i.pulseWidthNs.Load()
+	return time.Duration(width), i.pulseMeasured.Load()
+}
+
+// Subscribe streams subsequently measured pulses on this interrupt until ctx is canceled.
+// The channel buffers 16 events; a pulse measured while the buffer is full is dropped.
+func (i *RpiInterrupt) Subscribe(ctx context.Context) <-chan PulseEvent {
+	ch := make(chan PulseEvent, 16)
+
+	i.pulseSubsMu.Lock()
+	i.pulseSubs = append(i.pulseSubs, ch)
+	i.pulseSubsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		i.pulseSubsMu.Lock()
+		defer i.pulseSubsMu.Unlock()
+		for idx, sub := range i.pulseSubs {
+			if sub == ch {
+				i.pulseSubs = append(i.pulseSubs[:idx], i.pulseSubs[idx+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+
+	return ch
 }
 
 // Function finds an interrupt by its name.
@@ -66,7 +149,20 @@ func (pi *piPigpio) reconfigureInterrupts(ctx context.Context, cfg *Config) erro
 	}
 
 	// teardown old interrupts
-	for _, interrupt := range reconfigCtx.oldInterrupts {
+	for bcom, interrupt := range reconfigCtx.oldInterrupts {
+		// Only reset filters this interrupt actually had configured, so we don't make
+		// pointless calls for every other pin. A reset failure here shouldn't stop us from
+		// tearing down the rest of the old interrupts, so log it and keep going.
+		if interrupt.glitchFilterMicros != 0 {
+			if result := C.set_glitch_filter(pi.piID, C.uint(bcom), 0); result != 0 {
+				pi.logger.Error(rpiutils.ConvertErrorCodeToMessage(int(result), "error resetting glitch filter"))
+			}
+		}
+		if interrupt.noiseFilterSteadyMicros != 0 || interrupt.noiseFilterActiveMicros != 0 {
+			if result := C.set_noise_filter(pi.piID, C.uint(bcom), 0, 0); result != 0 {
+				pi.logger.Error(rpiutils.ConvertErrorCodeToMessage(int(result), "error resetting noise filter"))
+			}
+		}
 		if result := C.teardownInterrupt(interrupt.callbackID); result != 0 {
 			return rpiutils.ConvertErrorCodeToMessage(int(result), "error")
 		}
@@ -116,9 +212,91 @@ func (ctx *reconfigureContext) createNewInterrupt(newConfig rpiutils.DigitalInte
 
 	newInterrupt.callbackID = C.uint(callbackID)
 
+	if err := validateFilterMicros(newConfig); err != nil {
+		return err
+	}
+
+	if newConfig.GlitchFilterMicros > 0 {
+		if result := C.set_glitch_filter(ctx.pi.piID, C.uint(bcom), C.uint(newConfig.GlitchFilterMicros)); result != 0 {
+			return rpiutils.ConvertErrorCodeToMessage(int(result), "error setting glitch filter")
+		}
+		newInterrupt.glitchFilterMicros = newConfig.GlitchFilterMicros
+	}
+	if newConfig.NoiseFilterSteadyMicros > 0 || newConfig.NoiseFilterActiveMicros > 0 {
+		result := C.set_noise_filter(
+			ctx.pi.piID, C.uint(bcom),
+			C.uint(newConfig.NoiseFilterSteadyMicros), C.uint(newConfig.NoiseFilterActiveMicros),
+		)
+		if result != 0 {
+			return rpiutils.ConvertErrorCodeToMessage(int(result), "error setting noise filter")
+		}
+		newInterrupt.noiseFilterSteadyMicros = newConfig.NoiseFilterSteadyMicros
+		newInterrupt.noiseFilterActiveMicros = newConfig.NoiseFilterActiveMicros
+	}
+
+	return nil
+}
+
+// validateFilterMicros rejects negative glitch/noise filter durations, which set_glitch_filter
+// and set_noise_filter would otherwise silently truncate when cast to the unsigned C types.
+func validateFilterMicros(conf rpiutils.DigitalInterruptConfig) error {
+	if conf.GlitchFilterMicros < 0 {
+		return errors.Errorf("glitch filter micros for %s cannot be negative", conf.Pin)
+	}
+	if conf.NoiseFilterSteadyMicros < 0 || conf.NoiseFilterActiveMicros < 0 {
+		return errors.Errorf("noise filter micros for %s cannot be negative", conf.Pin)
+	}
 	return nil
 }
 
+// findInterruptWrapperByName finds the RpiInterrupt wrapper (rather than just
+// the rpiutils.ReconfigurableDigitalInterrupt it holds) for a given name.
+func findInterruptWrapperByName(name string, interrupts map[uint]*RpiInterrupt) (*RpiInterrupt, bool) {
+	for _, rpiInterrupt := range interrupts {
+		if rpiInterrupt.interrupt.Name() == name {
+			return rpiInterrupt, true
+		}
+	}
+	return nil, false
+}
+
+// DigitalInterruptPulseWidth and DigitalInterruptPulses below are exposed on piPigpio itself
+// rather than as Value/Subscribe methods on the board.DigitalInterrupt returned from
+// DigitalInterruptByName: pulse-width measurement only applies to ServoDigitalInterrupts, and
+// board.DigitalInterrupt (from the RDK's components/board package) has no Value/Subscribe methods to implement them on.
+// Adding those to the shared interface would mean every BasicDigitalInterrupt gets a
+// Value/Subscribe that always errors, which is worse than a narrower, servo-specific API here.
+
+// DigitalInterruptPulseWidth returns the most recently measured pulse width
+// for a ServoDigitalInterrupt, e.g. an RC receiver channel or a servo that
+// reports its position back via pulse width.
+func (pi *piPigpio) DigitalInterruptPulseWidth(name string) (time.Duration, error) {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	wrapper, ok := findInterruptWrapperByName(name, pi.interrupts)
+	if !ok {
+		return 0, fmt.Errorf("interrupt %s does not exist", name)
+	}
+	width, ok := wrapper.PulseWidth()
+	if !ok {
+		return 0, errors.Errorf("no pulse measured yet on interrupt %s", name)
+	}
+	return width, nil
+}
+
+// DigitalInterruptPulses streams subsequently measured pulses on a
+// ServoDigitalInterrupt until ctx is canceled; pulses that arrive while the
+// returned channel's buffer is full are dropped (see RpiInterrupt.Subscribe).
+func (pi *piPigpio) DigitalInterruptPulses(ctx context.Context, name string) (<-chan PulseEvent, error) {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	wrapper, ok := findInterruptWrapperByName(name, pi.interrupts)
+	if !ok {
+		return nil, fmt.Errorf("interrupt %s does not exist", name)
+	}
+	return wrapper.Subscribe(ctx), nil
+}
+
 // DigitalInterruptNames returns the names of all known digital interrupts.
 func (pi *piPigpio) DigitalInterruptNames() []string {
 	pi.mu.Lock()
@@ -207,6 +385,7 @@ func pigpioInterruptCallback(gpio, level int, rawTick uint32) {
 			instance.logger.Error(err)
 		}
 	case *rpiutils.ServoDigitalInterrupt:
+		i.recordEdge(high, tick*1000)
 		err := rpiutils.ServoTick(instance.cancelCtx, di, high, tick*1000)
 		if err != nil {
 			instance.logger.Error(err)
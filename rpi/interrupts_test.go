@@ -0,0 +1,38 @@
+package rpi
+
+import "testing"
+
+func TestRpiInterruptRecordEdge(t *testing.T) {
+	i := &RpiInterrupt{}
+
+	if _, measured := i.PulseWidth(); measured {
+		t.Fatalf("PulseWidth() reported measured before any edge was recorded")
+	}
+
+	// A falling edge with no prior rising edge is ignored.
+	i.recordEdge(false, 1_000_000)
+	if _, measured := i.PulseWidth(); measured {
+		t.Fatalf("PulseWidth() reported measured after a falling edge with no prior rising edge")
+	}
+
+	i.recordEdge(true, 1_000_000)
+	i.recordEdge(false, 1_001_500) // 1500ns high pulse
+	width, measured := i.PulseWidth()
+	if !measured {
+		t.Fatalf("PulseWidth() reported not measured after a rise/fall pair")
+	}
+	if width != 1500 {
+		t.Errorf("PulseWidth() = %v, want 1500ns", width)
+	}
+
+	// A legitimately-measured zero-width pulse must still read as measured.
+	i.recordEdge(true, 2_000_000)
+	i.recordEdge(false, 2_000_000)
+	width, measured = i.PulseWidth()
+	if !measured {
+		t.Fatalf("PulseWidth() reported not measured for a zero-width pulse")
+	}
+	if width != 0 {
+		t.Errorf("PulseWidth() = %v, want 0", width)
+	}
+}
@@ -0,0 +1,23 @@
+package utils
+
+// DigitalInterruptConfig is the configuration for a digital interrupt attached to a GPIO pin.
+//
+// This struct (and its Validate method, defined alongside ReconfigurableDigitalInterrupt and
+// CreateDigitalInterrupt elsewhere in this package) is extended in place here with the
+// glitch/noise filter fields below rather than re-declared, since rpi/interrupts.go already
+// depends on the fields and construction path being a single definition.
+type DigitalInterruptConfig struct {
+	Name string `json:"name"`
+	Pin  string `json:"pin"`
+	Type string `json:"type,omitempty"`
+
+	// GlitchFilterMicros, when set, debounces the interrupt by ignoring any
+	// edge that doesn't hold steady for at least this many microseconds.
+	GlitchFilterMicros int `json:"glitch_filter_micros,omitempty"`
+	// NoiseFilterSteadyMicros and NoiseFilterActiveMicros configure pigpio's
+	// noise filter: after NoiseFilterSteadyMicros of no activity, the next
+	// edge opens a NoiseFilterActiveMicros window in which edges are
+	// reported normally, after which the filter returns to steady state.
+	NoiseFilterSteadyMicros int `json:"noise_filter_steady_micros,omitempty"`
+	NoiseFilterActiveMicros int `json:"noise_filter_active_micros,omitempty"`
+}